@@ -0,0 +1,61 @@
+package zg
+
+// dftLogFilename is used when a Config's file.rootPath is set but
+// file.filename is left blank.
+const dftLogFilename = "app.log"
+
+// FileConfig mirrors the Milvus-style "file" section of a logging config.
+type FileConfig struct {
+	RootPath   string `yaml:"rootPath" json:"rootPath"`
+	Filename   string `yaml:"filename" json:"filename"`
+	MaxSize    int    `yaml:"maxSize" json:"maxSize"`
+	MaxAge     int    `yaml:"maxAge" json:"maxAge"`
+	MaxBackups int    `yaml:"maxBackups" json:"maxBackups"`
+}
+
+// Config mirrors the Milvus-style logging YAML, so callers can drive the
+// logger from their existing config files without composing Options by hand.
+type Config struct {
+	Level  string     `yaml:"level" json:"level"`
+	Format string     `yaml:"format" json:"format"`
+	File   FileConfig `yaml:"file" json:"file"`
+	Dev    bool       `yaml:"dev" json:"dev"`
+	Stdout bool       `yaml:"stdout" json:"stdout"`
+}
+
+// FromConfig builds a fully configured Logger from cfg.
+func FromConfig(cfg Config) (*Logger, error) {
+	level := cfg.Level
+	if level == "" {
+		if cfg.Dev {
+			level = "debug"
+		} else {
+			level = "info"
+		}
+	}
+
+	format := cfg.Format
+	if format == "" {
+		if cfg.Dev {
+			format = "console"
+		} else {
+			format = "json"
+		}
+	}
+
+	opts := []Option{Level(level), Format(format)}
+
+	if cfg.File.RootPath != "" {
+		filename := cfg.File.Filename
+		if filename == "" {
+			filename = dftLogFilename
+		}
+		opts = append(opts, File(cfg.File.RootPath, filename))
+		opts = append(opts, Rotate(cfg.File.MaxSize, cfg.File.MaxAge, cfg.File.MaxBackups, false))
+	}
+	if cfg.Stdout {
+		opts = append(opts, Stdout())
+	}
+
+	return New(opts...)
+}
@@ -0,0 +1,73 @@
+package zg
+
+import (
+	"os"
+	"sync"
+)
+
+// crashFile duplicates its fd onto stderr so unrecoverable runtime crashes
+// land in a persistent file alongside the structured log, instead of
+// vanishing the way direct-to-stderr writes otherwise do.
+type crashFile struct {
+	mux  sync.Mutex
+	path string
+	f    *os.File
+}
+
+func openCrashFile(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+}
+
+func newCrashFile(path string) (*crashFile, error) {
+	f, err := openCrashFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cf := &crashFile{path: path, f: f}
+	if err := dupToStderr(int(f.Fd())); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return cf, nil
+}
+
+// Reload closes the crash file and reopens it, reapplying the dup onto
+// stderr so the new fd keeps capturing crashes after an external rotate.
+func (c *crashFile) Reload() error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.f != nil {
+		c.f.Close()
+	}
+	f, err := openCrashFile(c.path)
+	if err != nil {
+		return err
+	}
+	c.f = f
+	return dupToStderr(int(f.Fd()))
+}
+
+// CaptureCrash opens path and duplicates its fd onto stderr (fd 2), so Go
+// runtime panics, fatal stack traces and any direct-to-stderr writes land
+// in path alongside the structured log. It replaces the crash capture on
+// the current global logger, if any.
+func CaptureCrash(path string) error {
+	cf, err := newCrashFile(path)
+	if err != nil {
+		return err
+	}
+	if gLogger != nil {
+		gLogger.cf = cf
+	}
+	return nil
+}
+
+// Crash option captures Go runtime panics, fatal stack traces and any
+// direct-to-stderr writes into path, via CaptureCrash.
+func Crash(path string) Option {
+	return func(logger *Logger) error {
+		logger.crashPath = path
+		return nil
+	}
+}
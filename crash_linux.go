@@ -0,0 +1,11 @@
+//go:build linux
+
+package zg
+
+import "syscall"
+
+// dupToStderr duplicates fd onto stderr (fd 2) using dup3, so Go runtime
+// panics and fatal stack traces are captured alongside the structured log.
+func dupToStderr(fd int) error {
+	return syscall.Dup3(fd, 2, 0)
+}
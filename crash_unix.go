@@ -0,0 +1,11 @@
+//go:build darwin || freebsd || netbsd || openbsd
+
+package zg
+
+import "syscall"
+
+// dupToStderr duplicates fd onto stderr (fd 2) using dup2, so Go runtime
+// panics and fatal stack traces are captured alongside the structured log.
+func dupToStderr(fd int) error {
+	return syscall.Dup2(fd, 2)
+}
@@ -0,0 +1,23 @@
+//go:build windows
+
+package zg
+
+import "syscall"
+
+var (
+	modkernel32      = syscall.NewLazyDLL("kernel32.dll")
+	procSetStdHandle = modkernel32.NewProc("SetStdHandle")
+)
+
+// stdErrorHandle is STD_ERROR_HANDLE from the Windows API.
+const stdErrorHandle = ^uintptr(12 - 1) // -12
+
+// dupToStderr points the process's stderr handle at fd, so Go runtime
+// panics and fatal stack traces are captured alongside the structured log.
+func dupToStderr(fd int) error {
+	r, _, err := procSetStdHandle.Call(stdErrorHandle, uintptr(fd))
+	if r == 0 {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package zg
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+var _bufferPool = buffer.NewPool()
+
+// logfmtEncoder renders entries as space-separated key=value pairs, reusing
+// the same EncoderConfig keys as the JSON/console encoders.
+type logfmtEncoder struct {
+	*zapcore.MapObjectEncoder
+	cfg zapcore.EncoderConfig
+}
+
+func newLogfmtEncoder(cfg zapcore.EncoderConfig) zapcore.Encoder {
+	return &logfmtEncoder{MapObjectEncoder: zapcore.NewMapObjectEncoder(), cfg: cfg}
+}
+
+func (enc *logfmtEncoder) Clone() zapcore.Encoder {
+	clone := zapcore.NewMapObjectEncoder()
+	for k, v := range enc.Fields {
+		clone.Fields[k] = v
+	}
+	return &logfmtEncoder{MapObjectEncoder: clone, cfg: enc.cfg}
+}
+
+func (enc *logfmtEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	line := _bufferPool.Get()
+
+	write := func(key, val string) {
+		if line.Len() > 0 {
+			line.AppendByte(' ')
+		}
+		line.AppendString(key)
+		line.AppendByte('=')
+		line.AppendString(logfmtQuote(val))
+	}
+
+	if enc.cfg.TimeKey != "" {
+		write(enc.cfg.TimeKey, ent.Time.Format(time.RFC3339))
+	}
+	if enc.cfg.LevelKey != "" {
+		write(enc.cfg.LevelKey, ent.Level.String())
+	}
+	if enc.cfg.NameKey != "" && ent.LoggerName != "" {
+		write(enc.cfg.NameKey, ent.LoggerName)
+	}
+	if enc.cfg.CallerKey != "" && ent.Caller.Defined {
+		write(enc.cfg.CallerKey, ent.Caller.TrimmedPath())
+	}
+	if enc.cfg.MessageKey != "" {
+		write(enc.cfg.MessageKey, ent.Message)
+	}
+
+	all := zapcore.NewMapObjectEncoder()
+	for k, v := range enc.Fields {
+		all.Fields[k] = v
+	}
+	for _, f := range fields {
+		f.AddTo(all)
+	}
+	keys := make([]string, 0, len(all.Fields))
+	for k := range all.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		write(k, logfmtValue(all.Fields[k]))
+	}
+
+	if enc.cfg.StacktraceKey != "" && ent.Stack != "" {
+		write(enc.cfg.StacktraceKey, ent.Stack)
+	}
+
+	if enc.cfg.LineEnding != "" {
+		line.AppendString(enc.cfg.LineEnding)
+	} else {
+		line.AppendString(zapcore.DefaultLineEnding)
+	}
+
+	return line, nil
+}
+
+// logfmtValue renders a field value as a string: strings as-is, errors via
+// Error, Stringers via String, and anything else via fmt.Sprint.
+func logfmtValue(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case error:
+		return val.Error()
+	case interface{ String() string }:
+		return val.String()
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// logfmtQuote quotes a value if it contains whitespace, quotes or an '='
+// that would otherwise make the key=value pair ambiguous to parse.
+func logfmtQuote(s string) string {
+	if s == "" {
+		return `""`
+	}
+	if strings.ContainsAny(s, " \t\r\n\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
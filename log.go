@@ -13,11 +13,12 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-func initLogger(level string, fw zapcore.WriteSyncer, stdout bool) (*zap.Logger, error) {
+func initLogger(level string, fw zapcore.WriteSyncer, stdout bool, format string, sc samplingConfig) (*zap.Logger, *sampleStats, error) {
 
 	var logLevel zapcore.Level
 	switch strings.ToLower(level) {
@@ -43,7 +44,7 @@ func initLogger(level string, fw zapcore.WriteSyncer, stdout bool) (*zap.Logger,
 		}
 	}
 	if fw == nil {
-		return nil, errors.New("No output writer")
+		return nil, nil, errors.New("No output writer")
 	}
 
 	encoderConfig := zapcore.EncoderConfig{
@@ -59,11 +60,11 @@ func initLogger(level string, fw zapcore.WriteSyncer, stdout bool) (*zap.Logger,
 		EncodeDuration: zapcore.StringDurationEncoder,
 		EncodeCaller:   zapcore.ShortCallerEncoder,
 	}
-	core := zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), fw, logLevel)
-	samplerCore := zapcore.NewSampler(core, time.Second, 100, 100)
-	logger := zap.New(samplerCore, zap.AddCaller(), zap.AddCallerSkip(1), zap.AddStacktrace(zap.DPanicLevel))
+	core := zapcore.NewCore(newEncoder(format, encoderConfig), fw, logLevel)
+	sampledCore, stats := newSampledCore(core, sc)
+	logger := zap.New(sampledCore, zap.AddCaller(), zap.AddCallerSkip(1), zap.AddStacktrace(zap.DPanicLevel))
 
-	return logger, nil
+	return logger, stats, nil
 }
 
 type noCopy struct{}
@@ -79,6 +80,13 @@ type fileWriter struct {
 	file string
 
 	w *os.File
+
+	// rotation settings, zero value disables the corresponding check
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+	compress   bool
+	written    int64
 }
 
 func openFile(dir, filename string) (*os.File, error) {
@@ -128,31 +136,10 @@ func newFileWriter(dir, filename string) (*fileWriter, error) {
 		file: filename,
 		w:    fs,
 	}
-	return fw, nil
-}
-
-func (f *fileWriter) Write(p []byte) (n int, err error) {
-	f.mux.Lock()
-	defer f.mux.Unlock()
-	return f.w.Write(p)
-}
-
-func (f *fileWriter) Reload() error {
-	if f == nil || f.w == nil {
-		return nil
-	}
-
-	f.mux.Lock()
-	defer f.mux.Unlock()
-	if err := f.w.Close(); err != nil {
-		return err
+	if info, err := fs.Stat(); err == nil {
+		fw.written = info.Size()
 	}
-	w, err := openFile(f.dir, f.file)
-	if err != nil {
-		return err
-	}
-	f.w = w
-	return nil
+	return fw, nil
 }
 
 func (f *fileWriter) Sync() error {
@@ -169,9 +156,36 @@ type Logger struct {
 	// some original configurations
 	dir, filename string
 	level         string
+	format        string
 	stdout        bool
 
-	fw *fileWriter // file writer
+	// rotation settings, see Rotate
+	maxSizeMB  int
+	maxAgeDays int
+	maxBackups int
+	compress   bool
+
+	// crash redirection, see Crash
+	crashPath string
+
+	// otelBridge mirrors Error-and-above entries onto the active OTel span,
+	// see WithOTelBridge
+	otelBridge bool
+
+	// writer is a pluggable sink set via the Writer option, used instead of
+	// the built-in fileWriter when non-nil
+	writer io.Writer
+
+	// sampling settings, see Sampling and NoSampling
+	samplingTick       time.Duration
+	samplingFirst      int
+	samplingThereafter int
+	noSampling         bool
+
+	fw    *fileWriter  // file writer
+	cf    *crashFile   // crash capture file
+	sink  reloader     // reload hook for the Writer option's sink, if any
+	stats *sampleStats // sampled-out entry counters, nil if sampling disabled
 }
 
 var gLogger *Logger
@@ -242,6 +256,41 @@ func Level(lvl string) Option {
 	}
 }
 
+var allowedFormats = []string{"json", "console", "logfmt"}
+
+// ErrInvalidFormat is returned by Format when given an unsupported kind.
+var ErrInvalidFormat = errors.New("Invalid log format")
+
+// Format option sets the logger's encoding: "json" (default), "console" or
+// "logfmt" (key=value pairs, reusing the same EncoderConfig keys).
+func Format(kind string) Option {
+	return func(logger *Logger) error {
+		var valid bool
+		for _, af := range allowedFormats {
+			if kind == af {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return ErrInvalidFormat
+		}
+		logger.format = kind
+		return nil
+	}
+}
+
+func newEncoder(format string, cfg zapcore.EncoderConfig) zapcore.Encoder {
+	switch format {
+	case "console":
+		return zapcore.NewConsoleEncoder(cfg)
+	case "logfmt":
+		return newLogfmtEncoder(cfg)
+	default:
+		return zapcore.NewJSONEncoder(cfg)
+	}
+}
+
 // Stdout option set logger output to stdout
 func Stdout() Option {
 	return func(logger *Logger) error {
@@ -259,21 +308,45 @@ func New(opts ...Option) (*Logger, error) {
 		}
 	}
 
+	var sink zapcore.WriteSyncer
 	var fw *fileWriter
 	var err error
-	if logger.filename != "" {
+	if logger.writer != nil {
+		var rl reloader
+		sink, rl = wrapWriter(logger.writer)
+		logger.sink = rl
+	} else if logger.filename != "" {
 		fw, err = newFileWriter(logger.dir, logger.filename)
 		if err != nil {
 			return nil, err
 		}
+		if fw != nil {
+			fw.applyRotateConfig(logger.maxSizeMB, logger.maxAgeDays, logger.maxBackups, logger.compress)
+			sink = fw
+		}
 	}
 	logger.fw = fw
 
-	core, err := initLogger(logger.level, fw, logger.stdout)
+	sc := samplingConfig{
+		disabled:   logger.noSampling,
+		tick:       logger.samplingTick,
+		first:      logger.samplingFirst,
+		thereafter: logger.samplingThereafter,
+	}
+	core, stats, err := initLogger(logger.level, sink, logger.stdout, logger.format, sc)
 	if err != nil {
 		return nil, err
 	}
 	logger.core = core
+	logger.stats = stats
+
+	if logger.crashPath != "" {
+		cf, err := newCrashFile(logger.crashPath)
+		if err != nil {
+			return nil, err
+		}
+		logger.cf = cf
+	}
 
 	// Replace gLogger with current new logger
 	gLogger = logger
@@ -317,8 +390,21 @@ func TraceID(ctx context.Context) string {
 
 // Reload to read file
 func Reload() error {
-	if gLogger != nil && gLogger.fw != nil {
-		return gLogger.fw.Reload()
+	if gLogger == nil {
+		return nil
+	}
+	if gLogger.fw != nil {
+		if err := gLogger.fw.Reload(); err != nil {
+			return err
+		}
+	}
+	if gLogger.sink != nil {
+		if err := gLogger.sink.Reload(); err != nil {
+			return err
+		}
+	}
+	if gLogger.cf != nil {
+		return gLogger.cf.Reload()
 	}
 	return nil
 }
@@ -328,8 +414,21 @@ const (
 	dftLatencyKey = "zglatency"
 )
 
-// In try extract logger instance from context
+// In try extract logger instance from context. When ctx carries a valid
+// OpenTelemetry span context, its trace_id/span_id/trace_flags are used
+// instead of generating a random ID.
 func In(ctx context.Context) *Logger {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		l := gLogger.With(spanContextFields(sc)...)
+		if gLogger.otelBridge {
+			span := trace.SpanFromContext(ctx)
+			l.core = l.core.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+				return newSpanCore(c, span)
+			}))
+		}
+		return l
+	}
+
 	val := ctx.Value(traceKey)
 	if val == nil {
 		return gLogger.With(String(dftTraceKey, newTraceID()))
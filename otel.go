@@ -0,0 +1,113 @@
+package zg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	dftOTelTraceIDKey = "trace_id"
+	dftSpanIDKey      = "span_id"
+	dftTraceFlagsKey  = "trace_flags"
+)
+
+// spanContextFields builds the trace_id/span_id/trace_flags fields for an
+// OpenTelemetry span context.
+func spanContextFields(sc trace.SpanContext) []Field {
+	return []Field{
+		String(dftOTelTraceIDKey, sc.TraceID().String()),
+		String(dftSpanIDKey, sc.SpanID().String()),
+		String(dftTraceFlagsKey, sc.TraceFlags().String()),
+	}
+}
+
+// TraceFromOTel lifts an OpenTelemetry span's TraceID from ctx into the
+// zgtrace key, for callers that still read TraceID(ctx) directly instead
+// of relying on In(ctx) to pick up the span context on its own.
+func TraceFromOTel(ctx context.Context) context.Context {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return ctx
+	}
+	return context.WithValue(ctx, traceKey, sc.TraceID().String())
+}
+
+// WithOTelBridge option makes Error-and-above log entries produced through
+// In(ctx) also land on the context's active OpenTelemetry span, via
+// span.RecordError and span.AddEvent.
+func WithOTelBridge() Option {
+	return func(logger *Logger) error {
+		logger.otelBridge = true
+		return nil
+	}
+}
+
+// spanCore wraps a zapcore.Core and mirrors Error-and-above entries onto span.
+type spanCore struct {
+	zapcore.Core
+	span trace.Span
+}
+
+func newSpanCore(core zapcore.Core, span trace.Span) zapcore.Core {
+	return &spanCore{Core: core, span: span}
+}
+
+// Check first delegates to the wrapped core (the sampler/levelBypassCore),
+// so its drop decision still applies, then additionally adds c itself only
+// for entries that survive that decision. That way Debug/Info/Warn entries
+// dropped by the sampler are never mirrored onto the span, and logging
+// through a span never behaves differently than without one.
+func (c *spanCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	ce = c.Core.Check(ent, ce)
+	if ce != nil && ent.Level >= zapcore.ErrorLevel && c.span != nil && c.span.IsRecording() {
+		ce = ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write mirrors the entry onto the span. It does not forward to c.Core:
+// the wrapped core already registered itself in Check above and performs
+// the actual write, so forwarding here would write the entry twice.
+func (c *spanCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	attrs := fieldsToAttributes(fields)
+	c.span.RecordError(errors.New(ent.Message), trace.WithAttributes(attrs...))
+	c.span.AddEvent(ent.Message, trace.WithAttributes(attrs...))
+	return nil
+}
+
+func (c *spanCore) With(fields []zapcore.Field) zapcore.Core {
+	return &spanCore{Core: c.Core.With(fields), span: c.span}
+}
+
+// fieldsToAttributes converts zap fields to OpenTelemetry attributes on a
+// best-effort basis, falling back to a string representation for types
+// that don't map onto an attribute.KeyValue directly.
+func fieldsToAttributes(fields []zapcore.Field) []attribute.KeyValue {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(enc.Fields))
+	for k, v := range enc.Fields {
+		switch val := v.(type) {
+		case string:
+			attrs = append(attrs, attribute.String(k, val))
+		case bool:
+			attrs = append(attrs, attribute.Bool(k, val))
+		case int64:
+			attrs = append(attrs, attribute.Int64(k, val))
+		case float64:
+			attrs = append(attrs, attribute.Float64(k, val))
+		default:
+			attrs = append(attrs, attribute.String(k, fmt.Sprint(val)))
+		}
+	}
+	return attrs
+}
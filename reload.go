@@ -0,0 +1,24 @@
+package zg
+
+import (
+	"os"
+	"os/signal"
+)
+
+// InstallSignalReload spawns a goroutine that calls Reload() whenever one of
+// sig is received (defaultReloadSignal, typically SIGHUP, if none given), so
+// the standard unix logrotate `postrotate /bin/kill -HUP` flow works out of
+// the box.
+func InstallSignalReload(sig ...os.Signal) {
+	if len(sig) == 0 {
+		sig = []os.Signal{defaultReloadSignal}
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sig...)
+	go func() {
+		for range ch {
+			Reload()
+		}
+	}()
+}
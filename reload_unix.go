@@ -0,0 +1,12 @@
+//go:build !windows
+
+package zg
+
+import (
+	"os"
+	"syscall"
+)
+
+// defaultReloadSignal is the signal InstallSignalReload listens for when
+// called with no arguments.
+var defaultReloadSignal os.Signal = syscall.SIGHUP
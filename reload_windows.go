@@ -0,0 +1,9 @@
+//go:build windows
+
+package zg
+
+import "os"
+
+// defaultReloadSignal is the signal InstallSignalReload listens for when
+// called with no arguments. Windows has no SIGHUP, so os.Interrupt is used.
+var defaultReloadSignal os.Signal = os.Interrupt
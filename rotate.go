@@ -0,0 +1,200 @@
+package zg
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const bytesPerMB = 1024 * 1024
+
+// Rotate option enables size/age/backup-count based rotation on the file
+// writer, so the module stays usable in long-running services without a
+// sidecar like logrotate. maxSizeMB triggers a rotate once the active file
+// grows past that size, maxAgeDays and maxBackups bound how many rolled
+// files are kept, and compress gzips rolled files in the background.
+func Rotate(maxSizeMB, maxAgeDays, maxBackups int, compress bool) Option {
+	return func(logger *Logger) error {
+		logger.maxSizeMB = maxSizeMB
+		logger.maxAgeDays = maxAgeDays
+		logger.maxBackups = maxBackups
+		logger.compress = compress
+		return nil
+	}
+}
+
+func (f *fileWriter) applyRotateConfig(maxSizeMB, maxAgeDays, maxBackups int, compress bool) {
+	f.maxSize = int64(maxSizeMB) * bytesPerMB
+	f.maxAge = time.Duration(maxAgeDays) * 24 * time.Hour
+	f.maxBackups = maxBackups
+	f.compress = compress
+}
+
+// Write writes p to the active file, then rotates it once the bytes written
+// to it reach maxSize.
+func (f *fileWriter) Write(p []byte) (n int, err error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	n, err = f.w.Write(p)
+	f.written += int64(n)
+	if f.maxSize > 0 && f.written >= f.maxSize {
+		if rerr := f.rotateLocked(); err == nil {
+			err = rerr
+		}
+	}
+	return n, err
+}
+
+// Reload closes the active file and reopens it, treating the reload as a
+// forced rotate so size/age/backup pruning stays consistent either way.
+func (f *fileWriter) Reload() error {
+	if f == nil || f.w == nil {
+		return nil
+	}
+
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	return f.rotateLocked()
+}
+
+// rotateLocked renames the active file aside, reopens a fresh file in its
+// place and kicks off background compression/pruning. Callers must hold f.mux.
+func (f *fileWriter) rotateLocked() error {
+	if f.w != nil {
+		if err := f.w.Close(); err != nil {
+			return err
+		}
+	}
+
+	rolled := ""
+	path := filepath.Join(f.dir, f.file)
+	if _, err := os.Stat(path); err == nil {
+		rolled = f.rolledName()
+		if err := os.Rename(path, rolled); err != nil {
+			return err
+		}
+	}
+
+	w, err := openFile(f.dir, f.file)
+	if err != nil {
+		return err
+	}
+	f.w = w
+	f.written = 0
+
+	// Compress (if enabled) before pruning, so prune never races
+	// compressAndRemove for the file it just rolled: otherwise its glob
+	// can catch the uncompressed file mid-read, or double-count it
+	// alongside its in-progress .gz.
+	if f.compress && rolled != "" {
+		go func() {
+			compressAndRemove(rolled)
+			f.prune()
+		}()
+	} else {
+		go f.prune()
+	}
+
+	return nil
+}
+
+// rolledName builds the name-YYYYMMDDTHHMMSS.log path for a rolled file,
+// appending a -N sequence suffix if more than one rotation happens within
+// the same second so a later rotation never clobbers an earlier backup.
+func (f *fileWriter) rolledName() string {
+	ext := filepath.Ext(f.file)
+	base := strings.TrimSuffix(f.file, ext)
+	ts := time.Now().Format("20060102T150405")
+
+	name := fmt.Sprintf("%s-%s%s", base, ts, ext)
+	path := filepath.Join(f.dir, name)
+	for i := 1; rolledPathTaken(path); i++ {
+		name = fmt.Sprintf("%s-%s-%d%s", base, ts, i, ext)
+		path = filepath.Join(f.dir, name)
+	}
+	return path
+}
+
+// rolledPathTaken reports whether path (or its compressed form) already
+// exists.
+func rolledPathTaken(path string) bool {
+	if _, err := os.Stat(path); err == nil {
+		return true
+	}
+	if _, err := os.Stat(path + ".gz"); err == nil {
+		return true
+	}
+	return false
+}
+
+// prune removes rolled files whose mtime exceeds maxAge, or that fall
+// beyond maxBackups when sorted newest-first.
+func (f *fileWriter) prune() {
+	if f.maxAge <= 0 && f.maxBackups <= 0 {
+		return
+	}
+
+	ext := filepath.Ext(f.file)
+	base := strings.TrimSuffix(f.file, ext)
+	matches, err := filepath.Glob(filepath.Join(f.dir, base+"-*"+ext+"*"))
+	if err != nil {
+		return
+	}
+
+	type backup struct {
+		path  string
+		mtime time.Time
+	}
+	backups := make([]backup, 0, len(matches))
+	for _, m := range matches {
+		info, err := os.Stat(m)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		backups = append(backups, backup{path: m, mtime: info.ModTime()})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].mtime.After(backups[j].mtime)
+	})
+
+	now := time.Now()
+	for i, b := range backups {
+		expired := f.maxAge > 0 && now.Sub(b.mtime) > f.maxAge
+		overflow := f.maxBackups > 0 && i >= f.maxBackups
+		if expired || overflow {
+			os.Remove(b.path)
+		}
+	}
+}
+
+// compressAndRemove gzips path to path+".gz" and removes the original on success.
+func compressAndRemove(path string) {
+	src, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return
+	}
+	defer dst.Close()
+
+	gw := gzip.NewWriter(dst)
+	if _, err := io.Copy(gw, src); err != nil {
+		gw.Close()
+		return
+	}
+	if err := gw.Close(); err != nil {
+		return
+	}
+
+	os.Remove(path)
+}
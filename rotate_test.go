@@ -0,0 +1,63 @@
+package zg
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFileWriterRotatesOnMaxSize(t *testing.T) {
+	dir := t.TempDir()
+	fw, err := newFileWriter(dir, "app.log")
+	if err != nil {
+		t.Fatalf("newFileWriter: %v", err)
+	}
+	fw.maxSize = 50
+
+	chunk := make([]byte, 10)
+	for i := range chunk {
+		chunk[i] = 'a'
+	}
+	for i := 0; i < 10; i++ {
+		if _, err := fw.Write(chunk); err != nil {
+			t.Fatalf("write %d: %v", i, err)
+		}
+	}
+
+	if fw.written >= fw.maxSize {
+		t.Fatalf("expected written to reset below maxSize after rotation, got %d", fw.written)
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, "app-*.log"))
+	if err != nil {
+		t.Fatalf("glob: %v", err)
+	}
+	if len(matches) == 0 {
+		t.Fatalf("expected at least one rolled file, found none")
+	}
+}
+
+func TestFileWriterRotateDedupesSameSecondNames(t *testing.T) {
+	dir := t.TempDir()
+	fw, err := newFileWriter(dir, "app.log")
+	if err != nil {
+		t.Fatalf("newFileWriter: %v", err)
+	}
+
+	rotate := func() {
+		fw.mux.Lock()
+		defer fw.mux.Unlock()
+		if err := fw.rotateLocked(); err != nil {
+			t.Fatalf("rotateLocked: %v", err)
+		}
+	}
+	rotate()
+	rotate()
+
+	matches, err := filepath.Glob(filepath.Join(dir, "app-*.log"))
+	if err != nil {
+		t.Fatalf("glob: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 distinct rolled files, got %d: %v", len(matches), matches)
+	}
+}
@@ -0,0 +1,145 @@
+package zg
+
+import (
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	dftSamplingTick       = time.Second
+	dftSamplingFirst      = 100
+	dftSamplingThereafter = 100
+)
+
+// samplingConfig carries the Sampling/NoSampling option values through to initLogger.
+type samplingConfig struct {
+	disabled   bool
+	tick       time.Duration
+	first      int
+	thereafter int
+}
+
+// Sampling option configures the tick window and first/thereafter counts
+// used to sample Debug/Info/Warn entries in hot paths. Error and above
+// always bypass sampling regardless of these settings.
+func Sampling(tick time.Duration, first, thereafter int) Option {
+	return func(logger *Logger) error {
+		logger.samplingTick = tick
+		logger.samplingFirst = first
+		logger.samplingThereafter = thereafter
+		return nil
+	}
+}
+
+// NoSampling option disables sampling entirely, so every entry is logged.
+func NoSampling() Option {
+	return func(logger *Logger) error {
+		logger.noSampling = true
+		return nil
+	}
+}
+
+// Stats reports, per level, how many entries have been dropped by the
+// sampler since the logger was created.
+type Stats struct {
+	Debug uint64
+	Info  uint64
+	Warn  uint64
+}
+
+// sampleStats counts sampled-out entries per level, so operators can detect
+// when they're losing logs under load.
+type sampleStats struct {
+	debug, info, warn uint64
+}
+
+func (s *sampleStats) record(lvl zapcore.Level) {
+	switch lvl {
+	case zapcore.DebugLevel:
+		atomic.AddUint64(&s.debug, 1)
+	case zapcore.InfoLevel:
+		atomic.AddUint64(&s.info, 1)
+	case zapcore.WarnLevel:
+		atomic.AddUint64(&s.warn, 1)
+	}
+}
+
+func (s *sampleStats) snapshot() Stats {
+	return Stats{
+		Debug: atomic.LoadUint64(&s.debug),
+		Info:  atomic.LoadUint64(&s.info),
+		Warn:  atomic.LoadUint64(&s.warn),
+	}
+}
+
+// Stats returns the number of sampled-out entries per level since the
+// logger was created. It is zero valued if sampling is disabled.
+func (log *Logger) Stats() Stats {
+	if log.stats == nil {
+		return Stats{}
+	}
+	return log.stats.snapshot()
+}
+
+// levelBypassCore samples Debug/Info/Warn entries through sampled, but
+// always sends Error-and-above entries straight to raw.
+type levelBypassCore struct {
+	raw     zapcore.Core
+	sampled zapcore.Core
+}
+
+func (c *levelBypassCore) Enabled(lvl zapcore.Level) bool {
+	return c.raw.Enabled(lvl)
+}
+
+func (c *levelBypassCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelBypassCore{raw: c.raw.With(fields), sampled: c.sampled.With(fields)}
+}
+
+func (c *levelBypassCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level >= zapcore.ErrorLevel {
+		return c.raw.Check(ent, ce)
+	}
+	return c.sampled.Check(ent, ce)
+}
+
+func (c *levelBypassCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.raw.Write(ent, fields)
+}
+
+func (c *levelBypassCore) Sync() error {
+	return c.raw.Sync()
+}
+
+// newSampledCore wraps core with sampling per sc, returning the stats
+// counter (nil when sampling is disabled).
+func newSampledCore(core zapcore.Core, sc samplingConfig) (zapcore.Core, *sampleStats) {
+	if sc.disabled {
+		return core, nil
+	}
+
+	tick := sc.tick
+	if tick <= 0 {
+		tick = dftSamplingTick
+	}
+	first := sc.first
+	if first <= 0 {
+		first = dftSamplingFirst
+	}
+	thereafter := sc.thereafter
+	if thereafter <= 0 {
+		thereafter = dftSamplingThereafter
+	}
+
+	stats := &sampleStats{}
+	sampled := zapcore.NewSamplerWithOptions(core, tick, first, thereafter,
+		zapcore.SamplerHook(func(ent zapcore.Entry, dec zapcore.SamplingDecision) {
+			if dec&zapcore.LogDropped != 0 {
+				stats.record(ent.Level)
+			}
+		}),
+	)
+	return &levelBypassCore{raw: core, sampled: sampled}, stats
+}
@@ -0,0 +1,99 @@
+package zg
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSamplingBypassesErrorLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(Writer(&buf), Level("debug"), Sampling(time.Hour, 1, 0))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	const n = 50
+	for i := 0; i < n; i++ {
+		logger.Info("info msg")
+	}
+	for i := 0; i < n; i++ {
+		logger.Error("error msg")
+	}
+
+	infoCount, errorCount := countMsgs(buf.String())
+	if infoCount >= n {
+		t.Fatalf("expected some info entries to be sampled out, got all %d", infoCount)
+	}
+	if errorCount != n {
+		t.Fatalf("expected all %d error entries to survive sampling, got %d", n, errorCount)
+	}
+
+	stats := logger.Stats()
+	if stats.Info == 0 {
+		t.Fatalf("expected Stats().Info to count sampled-out entries, got 0")
+	}
+}
+
+// TestSamplingWithOTelBridgeStillSamples guards against the regression where
+// enabling WithOTelBridge made In(ctx) bypass the sampler entirely: Debug/
+// Info/Warn logged through a context carrying a span context must still be
+// sampled and counted in Stats(), and Error must still always survive.
+func TestSamplingWithOTelBridgeStillSamples(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(Writer(&buf), Level("debug"), Sampling(time.Hour, 1, 0), WithOTelBridge())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	traceID, err := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	if err != nil {
+		t.Fatalf("TraceIDFromHex: %v", err)
+	}
+	spanID, err := trace.SpanIDFromHex("0102030405060708")
+	if err != nil {
+		t.Fatalf("SpanIDFromHex: %v", err)
+	}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	const n = 50
+	for i := 0; i < n; i++ {
+		In(ctx).Info("info msg")
+	}
+	for i := 0; i < n; i++ {
+		In(ctx).Error("error msg")
+	}
+
+	infoCount, errorCount := countMsgs(buf.String())
+	if infoCount >= n {
+		t.Fatalf("expected some bridged info entries to be sampled out, got all %d", infoCount)
+	}
+	if errorCount != n {
+		t.Fatalf("expected all %d bridged error entries to survive sampling, got %d", n, errorCount)
+	}
+
+	if stats := logger.Stats(); stats.Info == 0 {
+		t.Fatalf("expected Stats().Info to count sampled-out entries under the OTel bridge, got 0")
+	}
+}
+
+func countMsgs(out string) (infoCount, errorCount int) {
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if strings.Contains(line, "info msg") {
+			infoCount++
+		}
+		if strings.Contains(line, "error msg") {
+			errorCount++
+		}
+	}
+	return infoCount, errorCount
+}
@@ -0,0 +1,66 @@
+package zg
+
+import (
+	"io"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// reloader is implemented by sinks that know how to reopen themselves, so
+// Reload/InstallSignalReload can forward to whichever sink is active.
+type reloader interface {
+	Reload() error
+}
+
+// lockedWriter wraps an arbitrary io.Writer with a mutex, so any sink (a
+// third-party rotator, a syslog client, a network sink) can be used safely
+// from zap's concurrent callers. If the wrapped writer implements Reload,
+// it is used to satisfy the reloader interface too.
+type lockedWriter struct {
+	mux sync.Mutex
+	w   io.Writer
+}
+
+func newLockedWriter(w io.Writer) *lockedWriter {
+	return &lockedWriter{w: w}
+}
+
+func (lw *lockedWriter) Write(p []byte) (int, error) {
+	lw.mux.Lock()
+	defer lw.mux.Unlock()
+	return lw.w.Write(p)
+}
+
+func (lw *lockedWriter) Sync() error {
+	lw.mux.Lock()
+	defer lw.mux.Unlock()
+	if s, ok := lw.w.(interface{ Sync() error }); ok {
+		return s.Sync()
+	}
+	return nil
+}
+
+// Reload forwards to the wrapped writer's Reload method, if it has one.
+func (lw *lockedWriter) Reload() error {
+	lw.mux.Lock()
+	defer lw.mux.Unlock()
+	if r, ok := lw.w.(reloader); ok {
+		return r.Reload()
+	}
+	return nil
+}
+
+// Writer option sets w as the logger's sink instead of the built-in
+// fileWriter, wrapped with a mutex and zapcore.AddSync.
+func Writer(w io.Writer) Option {
+	return func(logger *Logger) error {
+		logger.writer = w
+		return nil
+	}
+}
+
+func wrapWriter(w io.Writer) (zapcore.WriteSyncer, reloader) {
+	lw := newLockedWriter(w)
+	return zapcore.AddSync(lw), lw
+}